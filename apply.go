@@ -11,41 +11,40 @@
 // You should not use this package.
 package filter // import "robpike.io/filter"
 
-import (
-	"reflect"
-)
-
-// Apply takes a slice of type []T and a function of type func(T) T. (If the
-// input conditions are not satisfied, Apply panics.) It returns a newly
-// allocated slice where each element is the result of calling the function on
-// successive elements of the slice.
+// Apply takes a slice of type []T and a function of type func(T) R. It
+// returns a newly allocated slice where each element is the result of
+// calling the function on successive elements of the slice.
 func Apply[T any, R any](slice []T, function func(T) R) []R {
-	return apply(slice, function, false)
+	out := make([]R, len(slice))
+	for i, s := range slice {
+		out[i] = function(s)
+	}
+	return out
 }
 
-// ApplyInPlace is like Apply, but overwrites the slice rather than returning a
-// newly allocated slice.
-func ApplyInPlace[T any, R any](slice []T, function func(T) R) {
-	apply(slice, function, true)
+// ApplyInPlaceSame is like Apply, but overwrites the slice rather than
+// returning a newly allocated slice. Unlike Apply, it requires function to
+// map T to T, since overwriting the slice in place only makes sense when the
+// result has the same type as the input.
+func ApplyInPlaceSame[T any](slice []T, function func(T) T) {
+	for i, s := range slice {
+		slice[i] = function(s)
+	}
 }
 
-// Choose takes a slice of type []T and a function of type func(T) bool. (If
-// the input conditions are not satisfied, Choose panics.) It returns a newly
-// allocated slice containing only those elements of the input slice that
-// satisfy the function.
+// Choose takes a slice of type []T and a function of type func(T) bool. It
+// returns a newly allocated slice containing only those elements of the
+// input slice that satisfy the function.
 func Choose[T any](slice []T, function func(T) bool) []T {
-	out, _ := chooseOrDrop(slice, function, false, true)
-	return out
+	return chooseOrDrop(slice, function, true)
 }
 
-// Drop takes a slice of type []T and a function of type func(T) bool. (If the
-// input conditions are not satisfied, Drop panics.) It returns a newly
-// allocated slice containing only those elements of the input slice that do
-// not satisfy the function, that is, it removes elements that satisfy the
-// function.
+// Drop takes a slice of type []T and a function of type func(T) bool. It
+// returns a newly allocated slice containing only those elements of the
+// input slice that do not satisfy the function, that is, it removes
+// elements that satisfy the function.
 func Drop[T any](slice []T, function func(T) bool) []T {
-	out, _ := chooseOrDrop(slice, function, false, false)
-	return out
+	return chooseOrDrop(slice, function, false)
 }
 
 // ChooseInPlace is like Choose, but overwrites the slice rather than returning
@@ -64,41 +63,23 @@ func DropInPlace[T any](pointerToSlice *[]T, function func(T) bool) {
 	chooseOrDropInPlace(pointerToSlice, function, false)
 }
 
-func apply[T any, R any](slice []T, function func(T) R, inPlace bool) []R {
-	var out []R
-	intype := reflect.TypeOf(slice)
-	outtype := reflect.TypeOf(out)
-	if inPlace && intype == outtype {
-		out = reflect.ValueOf(slice).Interface().([]R)
-	} else {
-		out = make([]R, len(slice))
-	}
-	for i, s := range slice {
-		out[i] = function(s)
-	}
-	return out
-}
-
-func chooseOrDropInPlace[T any](slice *[]T, function func(T) bool, truth bool) {
-	inp := reflect.ValueOf(slice)
-	if inp.Kind() != reflect.Ptr {
-		panic("choose/drop: not pointer to slice")
+func chooseOrDropInPlace[T any](pointerToSlice *[]T, function func(T) bool, truth bool) {
+	n := 0
+	for _, s := range *pointerToSlice {
+		if function(s) == truth {
+			(*pointerToSlice)[n] = s
+			n++
+		}
 	}
-	_, n := chooseOrDrop(*slice, function, true, truth)
-	inp.Elem().SetLen(n)
+	*pointerToSlice = (*pointerToSlice)[:n]
 }
 
-var boolType = reflect.ValueOf(true).Type()
-
-func chooseOrDrop[T any](slice []T, function func(T) bool, inPlace, truth bool) ([]T, int) {
-	var r []T
-	if inPlace {
-		r = slice[:0]
-	}
+func chooseOrDrop[T any](slice []T, function func(T) bool, truth bool) []T {
+	var out []T
 	for _, s := range slice {
 		if function(s) == truth {
-			r = append(r, s)
+			out = append(out, s)
 		}
 	}
-	return r, len(r)
+	return out
 }
@@ -0,0 +1,133 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filter
+
+import "testing"
+
+func TestApply(t *testing.T) {
+	got := Apply([]int{1, 2, 3}, func(v int) int { return v * v })
+	want := []int{1, 4, 9}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestApplyInPlaceSame(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	backing := s
+	ApplyInPlaceSame(s, func(v int) int { return v * 10 })
+	want := []int{10, 20, 30, 40}
+	for i := range want {
+		if s[i] != want[i] {
+			t.Errorf("got %v, want %v", s, want)
+		}
+	}
+	if &backing[0] != &s[0] {
+		t.Errorf("ApplyInPlaceSame should overwrite the backing array, not allocate a new one")
+	}
+}
+
+func TestChoose(t *testing.T) {
+	got := Choose([]int{1, 2, 3, 4, 5}, func(v int) bool { return v%2 == 0 })
+	want := []int{2, 4}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDrop(t *testing.T) {
+	got := Drop([]int{1, 2, 3, 4, 5}, func(v int) bool { return v%2 == 0 })
+	want := []int{1, 3, 5}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestChooseInPlace(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6}
+	backing := s
+	ChooseInPlace(&s, func(v int) bool { return v%2 == 0 })
+	want := []int{2, 4, 6}
+	if len(s) != len(want) {
+		t.Fatalf("got %v, want %v", s, want)
+	}
+	for i := range want {
+		if s[i] != want[i] {
+			t.Errorf("got %v, want %v", s, want)
+		}
+	}
+	if &backing[0] != &s[0] {
+		t.Errorf("ChooseInPlace should reuse the original backing array")
+	}
+}
+
+func TestChooseInPlaceNoneMatch(t *testing.T) {
+	s := []int{1, 3, 5}
+	ChooseInPlace(&s, func(v int) bool { return v%2 == 0 })
+	if len(s) != 0 {
+		t.Errorf("got %v, want empty", s)
+	}
+}
+
+func TestChooseInPlaceAllMatch(t *testing.T) {
+	s := []int{2, 4, 6}
+	ChooseInPlace(&s, func(v int) bool { return v%2 == 0 })
+	want := []int{2, 4, 6}
+	if len(s) != len(want) {
+		t.Fatalf("got %v, want %v", s, want)
+	}
+	for i := range want {
+		if s[i] != want[i] {
+			t.Errorf("got %v, want %v", s, want)
+		}
+	}
+}
+
+func TestDropInPlace(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6}
+	backing := s
+	DropInPlace(&s, func(v int) bool { return v%2 == 0 })
+	want := []int{1, 3, 5}
+	if len(s) != len(want) {
+		t.Fatalf("got %v, want %v", s, want)
+	}
+	for i := range want {
+		if s[i] != want[i] {
+			t.Errorf("got %v, want %v", s, want)
+		}
+	}
+	if &backing[0] != &s[0] {
+		t.Errorf("DropInPlace should reuse the original backing array")
+	}
+}
+
+// TestChooseInPlacePreservesRelativeOrder guards against the reslicing
+// rewrite corrupting the backing array when kept elements are not
+// contiguous in the original slice.
+func TestChooseInPlacePreservesRelativeOrder(t *testing.T) {
+	s := []int{5, 1, 4, 2, 3, 6}
+	ChooseInPlace(&s, func(v int) bool { return v%2 == 0 })
+	want := []int{4, 2, 6}
+	if len(s) != len(want) {
+		t.Fatalf("got %v, want %v", s, want)
+	}
+	for i := range want {
+		if s[i] != want[i] {
+			t.Errorf("got %v, want %v", s, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filter
+
+// Reduce takes a slice of type []T, an initial accumulator value of type A,
+// and a function of type func(A, T) A. It returns the result of folding the
+// function over the slice, left to right, starting from init.
+func Reduce[T, A any](slice []T, init A, function func(A, T) A) A {
+	acc := init
+	for _, s := range slice {
+		acc = function(acc, s)
+	}
+	return acc
+}
+
+// FlatMap takes a slice of type []T and a function of type func(T) []R. It
+// returns a newly allocated slice containing the concatenation of the
+// results of calling the function on successive elements of the slice.
+func FlatMap[T, R any](slice []T, function func(T) []R) []R {
+	var out []R
+	for _, s := range slice {
+		out = append(out, function(s)...)
+	}
+	return out
+}
+
+// GroupBy takes a slice of type []T and a function of type func(T) K. It
+// returns a map from each distinct key produced by the function to the
+// slice of elements, in their original order, that produced that key.
+func GroupBy[T any, K comparable](slice []T, function func(T) K) map[K][]T {
+	out := make(map[K][]T)
+	for _, s := range slice {
+		k := function(s)
+		out[k] = append(out[k], s)
+	}
+	return out
+}
+
+// Partition takes a slice of type []T and a function of type func(T) bool.
+// It returns two newly allocated slices: yes, containing the elements for
+// which the function reports true, and no, containing the rest, both in
+// their original order. Partition is equivalent to calling Choose and Drop,
+// but makes only one pass over slice.
+func Partition[T any](slice []T, function func(T) bool) (yes, no []T) {
+	for _, s := range slice {
+		if function(s) {
+			yes = append(yes, s)
+		} else {
+			no = append(no, s)
+		}
+	}
+	return yes, no
+}
+
+// Distinct takes a slice of type []T and returns a newly allocated slice
+// containing the elements of slice in their original order, with later
+// duplicates of an already-seen element removed.
+func Distinct[T comparable](slice []T) []T {
+	return DistinctBy(slice, func(t T) T { return t })
+}
+
+// DistinctBy is like Distinct, but uses the key returned by function, rather
+// than the element itself, to detect duplicates. The first element to
+// produce a given key is kept.
+func DistinctBy[T any, K comparable](slice []T, function func(T) K) []T {
+	seen := make(map[K]bool, len(slice))
+	var out []T
+	for _, s := range slice {
+		k := function(s)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, s)
+	}
+	return out
+}
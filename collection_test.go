@@ -0,0 +1,146 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filter
+
+import "testing"
+
+func TestReduce(t *testing.T) {
+	sum := Reduce([]int{1, 2, 3, 4}, 0, func(acc, v int) int { return acc + v })
+	if sum != 10 {
+		t.Errorf("got %d, want 10", sum)
+	}
+}
+
+func TestReduceEmpty(t *testing.T) {
+	sum := Reduce([]int(nil), 42, func(acc, v int) int { return acc + v })
+	if sum != 42 {
+		t.Errorf("got %d, want 42 (init unchanged)", sum)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	got := FlatMap([]int{1, 2, 3}, func(v int) []int { return []int{v, v} })
+	want := []int{1, 1, 2, 2, 3, 3}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFlatMapEmpty(t *testing.T) {
+	got := FlatMap([]int(nil), func(v int) []int { return []int{v} })
+	if len(got) != 0 {
+		t.Errorf("got %v, want empty", got)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	got := GroupBy([]int{1, 2, 3, 4, 5}, func(v int) bool { return v%2 == 0 })
+	wantEven := []int{2, 4}
+	wantOdd := []int{1, 3, 5}
+	if len(got[true]) != len(wantEven) {
+		t.Fatalf("got[true]=%v, want %v", got[true], wantEven)
+	}
+	for i := range wantEven {
+		if got[true][i] != wantEven[i] {
+			t.Errorf("got[true]=%v, want %v (order not preserved)", got[true], wantEven)
+		}
+	}
+	if len(got[false]) != len(wantOdd) {
+		t.Fatalf("got[false]=%v, want %v", got[false], wantOdd)
+	}
+	for i := range wantOdd {
+		if got[false][i] != wantOdd[i] {
+			t.Errorf("got[false]=%v, want %v (order not preserved)", got[false], wantOdd)
+		}
+	}
+}
+
+func TestGroupByEmpty(t *testing.T) {
+	got := GroupBy([]int(nil), func(v int) int { return v })
+	if len(got) != 0 {
+		t.Errorf("got %v, want empty map", got)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	yes, no := Partition([]int{1, 2, 3, 4, 5}, func(v int) bool { return v%2 == 0 })
+	wantYes := []int{2, 4}
+	wantNo := []int{1, 3, 5}
+	if len(yes) != len(wantYes) {
+		t.Fatalf("yes=%v, want %v", yes, wantYes)
+	}
+	for i := range wantYes {
+		if yes[i] != wantYes[i] {
+			t.Errorf("yes=%v, want %v (order not preserved)", yes, wantYes)
+		}
+	}
+	if len(no) != len(wantNo) {
+		t.Fatalf("no=%v, want %v", no, wantNo)
+	}
+	for i := range wantNo {
+		if no[i] != wantNo[i] {
+			t.Errorf("no=%v, want %v (order not preserved)", no, wantNo)
+		}
+	}
+}
+
+func TestPartitionEmpty(t *testing.T) {
+	yes, no := Partition([]int(nil), func(v int) bool { return true })
+	if len(yes) != 0 || len(no) != 0 {
+		t.Errorf("yes=%v no=%v, want both empty", yes, no)
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	got := Distinct([]int{1, 2, 2, 3, 1, 4})
+	want := []int{1, 2, 3, 4}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDistinctEmpty(t *testing.T) {
+	got := Distinct([]int(nil))
+	if len(got) != 0 {
+		t.Errorf("got %v, want empty", got)
+	}
+}
+
+// TestDistinctByKeepsFirstOccurrence guards against the doc comment's stated
+// contract (first element per key wins) being silently inverted.
+func TestDistinctByKeepsFirstOccurrence(t *testing.T) {
+	type pair struct {
+		key   int
+		value string
+	}
+	in := []pair{{1, "first"}, {2, "x"}, {1, "second"}, {2, "y"}}
+	got := DistinctBy(in, func(p pair) int { return p.key })
+	want := []pair{{1, "first"}, {2, "x"}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v (should keep first occurrence per key)", got, want)
+		}
+	}
+}
+
+func TestDistinctByEmpty(t *testing.T) {
+	got := DistinctBy([]int(nil), func(v int) int { return v })
+	if len(got) != 0 {
+		t.Errorf("got %v, want empty", got)
+	}
+}
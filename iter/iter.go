@@ -0,0 +1,133 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package iter provides filter, map, and reduce operations over iter.Seq
+// values, in the spirit of robpike.io/filter but built on Go 1.23's
+// range-over-func iterators instead of slices.
+//
+// The functions here compose: ApplySeq, ChooseSeq, and friends return new
+// iter.Seq values without materializing intermediate slices, so a pipeline
+// of them runs in a single pass over the source when finally ranged over.
+package iter // import "robpike.io/filter/iter"
+
+import "iter"
+
+// ApplySeq returns an iterator that yields function(v) for each v produced
+// by seq.
+func ApplySeq[T, R any](seq iter.Seq[T], function func(T) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for v := range seq {
+			if !yield(function(v)) {
+				return
+			}
+		}
+	}
+}
+
+// ChooseSeq returns an iterator over the elements of seq for which function
+// reports true.
+func ChooseSeq[T any](seq iter.Seq[T], function func(T) bool) iter.Seq[T] {
+	return chooseOrDrop(seq, function, true)
+}
+
+// DropSeq returns an iterator over the elements of seq for which function
+// reports false, that is, it removes elements that satisfy function.
+func DropSeq[T any](seq iter.Seq[T], function func(T) bool) iter.Seq[T] {
+	return chooseOrDrop(seq, function, false)
+}
+
+func chooseOrDrop[T any](seq iter.Seq[T], function func(T) bool, truth bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if function(v) == truth {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Reduce consumes seq, accumulating into init by calling fn(acc, v) for each
+// v produced by seq, and returns the final accumulator.
+func Reduce[T, A any](seq iter.Seq[T], init A, fn func(A, T) A) A {
+	acc := init
+	for v := range seq {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Take returns an iterator over at most the first n elements of seq.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}
+}
+
+// Drop returns an iterator over the elements of seq after the first n.
+func Drop[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk returns an iterator that groups the elements of seq into slices of
+// length n, except possibly the last, which may be shorter. Chunk panics if
+// n <= 0.
+func Chunk[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n <= 0 {
+		panic("iter: Chunk requires n > 0")
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, n)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, n)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Flatten returns an iterator over the concatenation of the slices produced
+// by seq.
+func Flatten[T any](seq iter.Seq[[]T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for chunk := range seq {
+			for _, v := range chunk {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package iter
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func count(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestApplySeqChooseSeqFusion(t *testing.T) {
+	seq := ChooseSeq(ApplySeq(count(6), func(i int) int { return i * i }), func(i int) bool { return i%2 == 0 })
+	got := slices.Collect(seq)
+	want := []int{0, 4, 16}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDropSeq(t *testing.T) {
+	got := slices.Collect(DropSeq(count(5), func(i int) bool { return i%2 == 0 }))
+	want := []int{1, 3}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestApplySeqEarlyTermination(t *testing.T) {
+	var seen []int
+	seq := ApplySeq(count(100), func(i int) int { return i })
+	for v := range seq {
+		seen = append(seen, v)
+		if v == 2 {
+			break
+		}
+	}
+	want := []int{0, 1, 2}
+	if !slices.Equal(seen, want) {
+		t.Errorf("got %v, want %v", seen, want)
+	}
+}
+
+func TestChooseSeqEarlyTermination(t *testing.T) {
+	var seen []int
+	for v := range ChooseSeq(count(100), func(i int) bool { return i%2 == 0 }) {
+		seen = append(seen, v)
+		if len(seen) == 3 {
+			break
+		}
+	}
+	want := []int{0, 2, 4}
+	if !slices.Equal(seen, want) {
+		t.Errorf("got %v, want %v", seen, want)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := Reduce(count(5), 0, func(acc, v int) int { return acc + v })
+	if sum != 10 {
+		t.Errorf("got %d, want 10", sum)
+	}
+}
+
+func TestTake(t *testing.T) {
+	got := slices.Collect(Take(count(10), 3))
+	want := []int{0, 1, 2}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestTakeMoreThanAvailable(t *testing.T) {
+	got := slices.Collect(Take(count(2), 10))
+	want := []int{0, 1}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDrop(t *testing.T) {
+	got := slices.Collect(Drop(count(5), 2))
+	want := []int{2, 3, 4}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestChunkExact(t *testing.T) {
+	var got [][]int
+	for c := range Chunk(count(6), 2) {
+		got = append(got, slices.Clone(c))
+	}
+	want := [][]int{{0, 1}, {2, 3}, {4, 5}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("chunk %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestChunkRemainder(t *testing.T) {
+	var got [][]int
+	for c := range Chunk(count(5), 2) {
+		got = append(got, slices.Clone(c))
+	}
+	want := [][]int{{0, 1}, {2, 3}, {4}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("chunk %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestChunkEarlyTermination(t *testing.T) {
+	var got [][]int
+	for c := range Chunk(count(100), 2) {
+		got = append(got, slices.Clone(c))
+		if len(got) == 2 {
+			break
+		}
+	}
+	want := [][]int{{0, 1}, {2, 3}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("chunk %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestChunkPanicsOnNonPositiveN(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for n <= 0")
+		}
+	}()
+	for range Chunk(count(3), 0) {
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	chunks := func(yield func([]int) bool) {
+		for _, c := range [][]int{{1, 2}, {3}, {4, 5, 6}} {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+	got := slices.Collect(Flatten[int](chunks))
+	want := []int{1, 2, 3, 4, 5, 6}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFlattenEarlyTermination(t *testing.T) {
+	chunks := func(yield func([]int) bool) {
+		for _, c := range [][]int{{1, 2}, {3, 4}, {5, 6}} {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+	var got []int
+	for v := range Flatten[int](chunks) {
+		got = append(got, v)
+		if v == 3 {
+			break
+		}
+	}
+	want := []int{1, 2, 3}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestChunkFlattenRoundTrip(t *testing.T) {
+	got := slices.Collect(Flatten(Chunk(count(7), 3)))
+	want := []int{0, 1, 2, 3, 4, 5, 6}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filter
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// ApplyParallel is like Apply, but distributes the calls to function across
+// workers goroutines. If workers is 0 or negative, runtime.NumCPU() is used
+// instead. The order of the returned slice matches the order of slice,
+// regardless of the order in which the goroutines complete.
+func ApplyParallel[T, R any](slice []T, function func(T) R, workers int) []R {
+	out := make([]R, len(slice))
+	forEachIndex(len(slice), workers, func(i int) {
+		out[i] = function(slice[i])
+	})
+	return out
+}
+
+// ChooseParallel is like Choose, but evaluates function across workers
+// goroutines. If workers is 0 or negative, runtime.NumCPU() is used
+// instead. The relative order of the chosen elements matches their order
+// in slice.
+func ChooseParallel[T any](slice []T, function func(T) bool, workers int) []T {
+	keep := make([]bool, len(slice))
+	forEachIndex(len(slice), workers, func(i int) {
+		keep[i] = function(slice[i])
+	})
+	var out []T
+	for i, k := range keep {
+		if k {
+			out = append(out, slice[i])
+		}
+	}
+	return out
+}
+
+// ApplyParallelContext is like ApplyParallel, but function may return an
+// error and ctx may be used to cancel the remaining work. ApplyParallelContext
+// returns as soon as every worker has stopped. If one or more calls to
+// function returned an error, the returned error is the one at the lowest
+// index in slice, or nil if function never returned one. Since workers take
+// indices in order but run concurrently, elements at any index, not only
+// those after the one that errored, may be left at their zero value. If ctx is
+// canceled for a reason other than a function error, that cancellation's
+// error is returned instead.
+func ApplyParallelContext[T, R any](ctx context.Context, slice []T, function func(T) (R, error), workers int) ([]R, error) {
+	out := make([]R, len(slice))
+	errs := make([]error, len(slice))
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	forEachIndex(len(slice), workers, func(i int) {
+		if ctx.Err() != nil {
+			return
+		}
+		r, err := function(slice[i])
+		if err != nil {
+			errs[i] = err
+			cancel(err)
+			return
+		}
+		out[i] = r
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return out, err
+		}
+	}
+	if err := context.Cause(ctx); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// forEachIndex calls fn(i) for every i in [0, n), distributing the calls
+// across workers goroutines and blocking until all of them return. If
+// workers is 0 or negative, runtime.NumCPU() is used instead; if workers > n,
+// only n goroutines are started.
+func forEachIndex(n, workers int, fn func(i int)) {
+	if n == 0 {
+		return
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indices := make(chan int)
+	go func() {
+		defer close(indices)
+		for i := 0; i < n; i++ {
+			indices <- i
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
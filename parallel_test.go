@@ -0,0 +1,96 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filter
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestApplyParallel(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5}
+	for _, workers := range []int{0, 1, 2, len(in), len(in) * 2, -1} {
+		got := ApplyParallel(in, func(x int) int { return x * 2 }, workers)
+		want := []int{2, 4, 6, 8, 10}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("workers=%d: got %v, want %v", workers, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestApplyParallelEmpty(t *testing.T) {
+	got := ApplyParallel([]int(nil), func(x int) int { return x }, 4)
+	if len(got) != 0 {
+		t.Errorf("got %v, want empty", got)
+	}
+}
+
+func TestChooseParallel(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6}
+	got := ChooseParallel(in, func(x int) bool { return x%2 == 0 }, 3)
+	want := []int{2, 4, 6}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestApplyParallelContext(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5}
+	got, err := ApplyParallelContext(context.Background(), in, func(x int) (int, error) {
+		return x * 2, nil
+	}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []int{2, 4, 6, 8, 10}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestApplyParallelContextFunctionError(t *testing.T) {
+	errBoom := errors.New("boom")
+	in := []int{1, 2, 3}
+	_, err := ApplyParallelContext(context.Background(), in, func(x int) (int, error) {
+		if x == 2 {
+			return 0, errBoom
+		}
+		return x, nil
+	}, 1)
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("got %v, want %v", err, errBoom)
+	}
+}
+
+func TestApplyParallelContextPreCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	in := make([]int, 1000)
+	_, err := ApplyParallelContext(ctx, in, func(x int) (int, error) {
+		return x, nil
+	}, 4)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+}
+
+func TestForEachIndexNegativeWorkers(t *testing.T) {
+	in := []int{1, 2, 3}
+	got := ApplyParallel(in, func(x int) int { return x }, -5)
+	if len(got) != len(in) {
+		t.Fatalf("got %v, want %v", got, in)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+)
+
+// jsonLinesCodec decodes and encodes one JSON value per record, reading
+// newline-separated JSON objects and writing one object per line.
+type jsonLinesCodec[T any] struct {
+	dec *json.Decoder
+	enc *json.Encoder
+}
+
+// NewJSONLinesDecoder returns a Decoder that reads successive
+// newline-delimited JSON values of type T from r.
+func NewJSONLinesDecoder[T any](r io.Reader) Decoder[T] {
+	return &jsonLinesCodec[T]{dec: json.NewDecoder(r)}
+}
+
+// NewJSONLinesEncoder returns an Encoder that writes values of type T to w
+// as newline-delimited JSON.
+func NewJSONLinesEncoder[T any](w io.Writer) Encoder[T] {
+	return &jsonLinesCodec[T]{enc: json.NewEncoder(w)}
+}
+
+func (c *jsonLinesCodec[T]) Decode() (T, error) {
+	var v T
+	err := c.dec.Decode(&v)
+	return v, err
+}
+
+func (c *jsonLinesCodec[T]) Encode(v T) error {
+	return c.enc.Encode(v)
+}
+
+// gobCodec decodes and encodes one gob-encoded value of type T per record.
+type gobCodec[T any] struct {
+	dec *gob.Decoder
+	enc *gob.Encoder
+}
+
+// NewGobDecoder returns a Decoder that reads successive gob-encoded values
+// of type T from r.
+func NewGobDecoder[T any](r io.Reader) Decoder[T] {
+	return &gobCodec[T]{dec: gob.NewDecoder(r)}
+}
+
+// NewGobEncoder returns an Encoder that writes values of type T to w as gob.
+func NewGobEncoder[T any](w io.Writer) Encoder[T] {
+	return &gobCodec[T]{enc: gob.NewEncoder(w)}
+}
+
+func (c *gobCodec[T]) Decode() (T, error) {
+	var v T
+	err := c.dec.Decode(&v)
+	return v, err
+}
+
+func (c *gobCodec[T]) Encode(v T) error {
+	return c.enc.Encode(v)
+}
+
+// csvDecoder decodes one CSV record at a time into a T via parse.
+type csvDecoder[T any] struct {
+	r     *csv.Reader
+	parse func([]string) (T, error)
+}
+
+// NewCSVDecoder returns a Decoder that reads successive CSV records from r,
+// converting each into a T with parse.
+func NewCSVDecoder[T any](r io.Reader, parse func([]string) (T, error)) Decoder[T] {
+	return &csvDecoder[T]{r: csv.NewReader(r), parse: parse}
+}
+
+func (c *csvDecoder[T]) Decode() (T, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return c.parse(record)
+}
+
+// csvEncoder encodes each T as one CSV record via format.
+type csvEncoder[T any] struct {
+	w      *csv.Writer
+	format func(T) []string
+}
+
+// NewCSVEncoder returns an Encoder that writes values of type T to w as CSV
+// records, converting each with format. The writer is flushed after every
+// Encode call.
+func NewCSVEncoder[T any](w io.Writer, format func(T) []string) Encoder[T] {
+	return &csvEncoder[T]{w: csv.NewWriter(w), format: format}
+}
+
+func (c *csvEncoder[T]) Encode(v T) error {
+	if err := c.w.Write(c.format(v)); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
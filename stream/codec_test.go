@@ -0,0 +1,149 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"testing"
+)
+
+type record struct {
+	Name string
+	N    int
+}
+
+func decodeAll[T any](dec Decoder[T]) ([]T, error) {
+	var out []T
+	for {
+		v, err := dec.Decode()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, v)
+	}
+}
+
+func TestJSONLinesRoundTrip(t *testing.T) {
+	in := []record{{"a", 1}, {"b", 2}, {"c", 3}}
+
+	var buf bytes.Buffer
+	enc := NewJSONLinesEncoder[record](&buf)
+	for _, r := range in {
+		if err := enc.Encode(r); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+	}
+
+	dec := NewJSONLinesDecoder[record](&buf)
+	got, err := decodeAll[record](dec)
+	if err != nil {
+		t.Fatalf("decodeAll: %v", err)
+	}
+	if len(got) != len(in) {
+		t.Fatalf("got %v, want %v", got, in)
+	}
+	for i := range in {
+		if got[i] != in[i] {
+			t.Errorf("got %v, want %v", got[i], in[i])
+		}
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	in := []record{{"x", 10}, {"y", 20}}
+
+	var buf bytes.Buffer
+	enc := NewGobEncoder[record](&buf)
+	for _, r := range in {
+		if err := enc.Encode(r); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+	}
+
+	dec := NewGobDecoder[record](&buf)
+	got, err := decodeAll[record](dec)
+	if err != nil {
+		t.Fatalf("decodeAll: %v", err)
+	}
+	if len(got) != len(in) {
+		t.Fatalf("got %v, want %v", got, in)
+	}
+	for i := range in {
+		if got[i] != in[i] {
+			t.Errorf("got %v, want %v", got[i], in[i])
+		}
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	in := []record{{"a", 1}, {"b", 2}, {"c", 3}}
+
+	var buf bytes.Buffer
+	enc := NewCSVEncoder(&buf, func(r record) []string {
+		return []string{r.Name, strconv.Itoa(r.N)}
+	})
+	for _, r := range in {
+		if err := enc.Encode(r); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+	}
+
+	dec := NewCSVDecoder(&buf, func(row []string) (record, error) {
+		n, err := strconv.Atoi(row[1])
+		if err != nil {
+			return record{}, err
+		}
+		return record{Name: row[0], N: n}, nil
+	})
+	got, err := decodeAll[record](dec)
+	if err != nil {
+		t.Fatalf("decodeAll: %v", err)
+	}
+	if len(got) != len(in) {
+		t.Fatalf("got %v, want %v", got, in)
+	}
+	for i := range in {
+		if got[i] != in[i] {
+			t.Errorf("got %v, want %v", got[i], in[i])
+		}
+	}
+}
+
+func TestJSONLinesChooseStream(t *testing.T) {
+	var in bytes.Buffer
+	srcEnc := NewJSONLinesEncoder[record](&in)
+	for _, r := range []record{{"a", 1}, {"b", 2}, {"c", 3}} {
+		if err := srcEnc.Encode(r); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	dec := NewJSONLinesDecoder[record](&in)
+	enc := NewJSONLinesEncoder[record](&out)
+	err := ChooseStream[record](dec, func(r record) bool { return r.N%2 == 1 }, enc)
+	if err != nil {
+		t.Fatalf("ChooseStream: %v", err)
+	}
+
+	got, err := decodeAll[record](NewJSONLinesDecoder[record](&out))
+	if err != nil {
+		t.Fatalf("decodeAll: %v", err)
+	}
+	want := []record{{"a", 1}, {"c", 3}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got[i], want[i])
+		}
+	}
+}
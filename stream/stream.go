@@ -0,0 +1,145 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stream provides Choose and Apply over records read one at a time
+// from an io.Reader, for datasets too large to hold in a slice. It is the
+// streaming counterpart to robpike.io/filter, decoding a record, running the
+// predicate or function on it, and encoding the survivors as it goes rather
+// than loading everything into memory first.
+package stream // import "robpike.io/filter/stream"
+
+import (
+	"errors"
+	"io"
+)
+
+// Decoder produces a stream of values of type T, one at a time. Decode
+// returns io.EOF once the stream is exhausted.
+type Decoder[T any] interface {
+	Decode() (T, error)
+}
+
+// Encoder consumes a stream of values of type T, one at a time.
+type Encoder[T any] interface {
+	Encode(T) error
+}
+
+// options holds the settings controlled by Option values.
+type options struct {
+	batch int
+}
+
+// Option configures a ChooseStream or ApplyStream call.
+type Option func(*options)
+
+// Batch sets the number of records buffered before they are written to the
+// Encoder. A full buffer is written by calling Encode once per buffered
+// record, so Batch does not reduce the number of Encode calls. It does not
+// change how function is called either: ChooseStream and ApplyStream always
+// call function once per decoded record. The default batch size is 1.
+func Batch(n int) Option {
+	return func(o *options) {
+		o.batch = n
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{batch: 1}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.batch < 1 {
+		o.batch = 1
+	}
+	return o
+}
+
+// ChooseStream reads values from dec until it is exhausted, writes to enc
+// those values for which function reports true, and returns the first error
+// encountered from dec or enc. A clean end of the input, signaled by
+// io.EOF, is not reported as an error. If dec returns any other error, the
+// chosen values already buffered but not yet handed to enc are flushed
+// before that error is returned, so a mid-stream failure does not silently
+// discard survivors already selected. If that flush itself fails, the error
+// from enc is returned in place of the error from dec.
+func ChooseStream[T any](dec Decoder[T], function func(T) bool, enc Encoder[T], opts ...Option) error {
+	o := newOptions(opts)
+	batch := make([]T, 0, o.batch)
+	flush := func() error {
+		for _, v := range batch {
+			if err := enc.Encode(v); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+	for {
+		v, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return flush()
+		}
+		if err != nil {
+			if ferr := flush(); ferr != nil {
+				return ferr
+			}
+			return err
+		}
+		if function(v) {
+			batch = append(batch, v)
+			if len(batch) == o.batch {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// ApplyStream reads values from dec until it is exhausted, writes
+// function(v) to enc for each value v read, and returns the first error
+// encountered from dec, function, or enc. A clean end of the input, signaled
+// by io.EOF, is not reported as an error. If dec or function returns any
+// other error, the results already buffered but not yet handed to enc are
+// flushed before that error is returned, so a mid-stream failure does not
+// silently discard results already computed. If that flush itself fails, the
+// error from enc is returned in its place.
+func ApplyStream[T, R any](dec Decoder[T], function func(T) (R, error), enc Encoder[R], opts ...Option) error {
+	o := newOptions(opts)
+	batch := make([]R, 0, o.batch)
+	flush := func() error {
+		for _, v := range batch {
+			if err := enc.Encode(v); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+	for {
+		v, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return flush()
+		}
+		if err != nil {
+			if ferr := flush(); ferr != nil {
+				return ferr
+			}
+			return err
+		}
+		r, err := function(v)
+		if err != nil {
+			if ferr := flush(); ferr != nil {
+				return ferr
+			}
+			return err
+		}
+		batch = append(batch, r)
+		if len(batch) == o.batch {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
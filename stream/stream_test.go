@@ -0,0 +1,166 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+// sliceDecoder decodes the elements of a slice in order, then returns err
+// (io.EOF by default) forever.
+type sliceDecoder[T any] struct {
+	values []T
+	err    error
+	i      int
+}
+
+func (d *sliceDecoder[T]) Decode() (T, error) {
+	if d.i >= len(d.values) {
+		var zero T
+		err := d.err
+		if err == nil {
+			err = io.EOF
+		}
+		return zero, err
+	}
+	v := d.values[d.i]
+	d.i++
+	return v, nil
+}
+
+// recordingEncoder appends every encoded value to got, failing with err
+// once it has recorded failAfter values (failAfter < 0 disables failure).
+type recordingEncoder[T any] struct {
+	got       []T
+	err       error
+	failAfter int
+}
+
+func (e *recordingEncoder[T]) Encode(v T) error {
+	if e.failAfter >= 0 && len(e.got) >= e.failAfter {
+		return e.err
+	}
+	e.got = append(e.got, v)
+	return nil
+}
+
+func TestChooseStreamBatchFlushesOnEOF(t *testing.T) {
+	dec := &sliceDecoder[int]{values: []int{1, 2, 3, 4, 5}}
+	enc := &recordingEncoder[int]{failAfter: -1}
+	err := ChooseStream[int](dec, func(v int) bool { return v%2 == 1 }, enc, Batch(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []int{1, 3, 5}
+	if len(enc.got) != len(want) {
+		t.Fatalf("got %v, want %v", enc.got, want)
+	}
+	for i := range want {
+		if enc.got[i] != want[i] {
+			t.Errorf("got %v, want %v", enc.got, want)
+		}
+	}
+}
+
+func TestApplyStreamBatchFlushesOnEOF(t *testing.T) {
+	dec := &sliceDecoder[int]{values: []int{1, 2, 3, 4, 5}}
+	enc := &recordingEncoder[int]{failAfter: -1}
+	err := ApplyStream[int, int](dec, func(v int) (int, error) { return v * 10, nil }, enc, Batch(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []int{10, 20, 30, 40, 50}
+	if len(enc.got) != len(want) {
+		t.Fatalf("got %v, want %v", enc.got, want)
+	}
+	for i := range want {
+		if enc.got[i] != want[i] {
+			t.Errorf("got %v, want %v", enc.got, want)
+		}
+	}
+}
+
+func TestChooseStreamDecodeErrorFlushesPendingBatch(t *testing.T) {
+	errBoom := errors.New("boom")
+	dec := &sliceDecoder[int]{values: []int{1, 2}, err: errBoom}
+	enc := &recordingEncoder[int]{failAfter: -1}
+	err := ChooseStream[int](dec, func(v int) bool { return true }, enc, Batch(10))
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("got %v, want %v", err, errBoom)
+	}
+	want := []int{1, 2}
+	if len(enc.got) != len(want) {
+		t.Fatalf("buffered values were dropped: got %v, want %v", enc.got, want)
+	}
+	for i := range want {
+		if enc.got[i] != want[i] {
+			t.Errorf("got %v, want %v", enc.got, want)
+		}
+	}
+}
+
+func TestApplyStreamFunctionErrorFlushesPendingBatch(t *testing.T) {
+	errBoom := errors.New("boom")
+	dec := &sliceDecoder[int]{values: []int{1, 2, 3}}
+	enc := &recordingEncoder[int]{failAfter: -1}
+	err := ApplyStream[int, int](dec, func(v int) (int, error) {
+		if v == 3 {
+			return 0, errBoom
+		}
+		return v, nil
+	}, enc, Batch(10))
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("got %v, want %v", err, errBoom)
+	}
+	want := []int{1, 2}
+	if len(enc.got) != len(want) {
+		t.Fatalf("buffered values were dropped: got %v, want %v", enc.got, want)
+	}
+	for i := range want {
+		if enc.got[i] != want[i] {
+			t.Errorf("got %v, want %v", enc.got, want)
+		}
+	}
+}
+
+func TestChooseStreamEncodeErrorSurfaces(t *testing.T) {
+	errBoom := errors.New("boom")
+	dec := &sliceDecoder[int]{values: []int{1, 2, 3}}
+	enc := &recordingEncoder[int]{failAfter: 1, err: errBoom}
+	err := ChooseStream[int](dec, func(v int) bool { return true }, enc, Batch(1))
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("got %v, want %v", err, errBoom)
+	}
+	if len(enc.got) != 1 {
+		t.Fatalf("got %v, want exactly 1 value written before the failure", enc.got)
+	}
+}
+
+func TestChooseStreamDefaultBatchIsOne(t *testing.T) {
+	dec := &sliceDecoder[int]{values: []int{1, 2, 3}}
+	enc := &recordingEncoder[int]{failAfter: -1}
+	if err := ChooseStream[int](dec, func(v int) bool { return true }, enc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []int{1, 2, 3}
+	for i := range want {
+		if enc.got[i] != want[i] {
+			t.Errorf("got %v, want %v", enc.got, want)
+		}
+	}
+}
+
+func TestBatchNonPositiveClampsToOne(t *testing.T) {
+	dec := &sliceDecoder[int]{values: []int{1, 2}}
+	enc := &recordingEncoder[int]{failAfter: -1}
+	if err := ChooseStream[int](dec, func(v int) bool { return true }, enc, Batch(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(enc.got) != 2 {
+		t.Fatalf("got %v, want 2 values", enc.got)
+	}
+}